@@ -0,0 +1,264 @@
+package lru
+
+import (
+	"container/list"
+)
+
+type arcListKind int
+
+const (
+	arcT1 arcListKind = iota // 最近只被访问过一次的条目
+	arcT2                    // 最近被访问过至少两次的条目（频率更高）
+	arcB1                    // 从 T1 淘汰出去的 key 的“幽灵”记录，只记 key 不记 value
+	arcB2                    // 从 T2 淘汰出去的 key 的“幽灵”记录，只记 key 不记 value
+)
+
+// arcNode 是四条链表共用的节点类型。T1/T2 中 value 有效，B1/B2 只用来记住 key 最近出现过，value 为 nil
+type arcNode struct {
+	key   string
+	value Value
+	list  arcListKind
+}
+
+// ARCCache 实现自适应替换缓存（Adaptive Replacement Cache）。
+// 原始论文里 T1/T2/B1/B2 的大小和目标值 p、容量 c 都是按“条目数”计的，
+// 但这个包里缓存容量统一用字节数（maxBytes）表示。这里做了一个实用的折中：
+// p/c 的自适应公式仍然按论文使用 B1、B2 的条目数计算（这部分和字节大小无关），
+// 而“c”则取当前 T1+T2 的条目数，随着字节预算下的淘汰自然伸缩；
+// 真正的容量约束通过 Add 末尾的“while nbytes > maxBytes { replace() }”来保证，
+// 这样既保留了 ARC 在“近期性”和“频率”之间自适应的核心行为，又能复用包里一贯的字节预算语义。
+type ARCCache struct {
+	maxBytes int
+	nbytes   int // T1 + T2 中实际值的字节数，B1/B2 不计入（它们不持有 value）
+	p        int // T1 的目标条目数
+
+	t1, t2, b1, b2 *list.List
+	nodes          map[string]*list.Element
+
+	OnEvicted func(key string, value Value)
+}
+
+// NewARC 创建一个 ARC 策略的缓存
+func NewARC(maxBytes int, onEvicted func(string, Value)) *ARCCache {
+	return &ARCCache{
+		maxBytes:  maxBytes,
+		t1:        list.New(),
+		t2:        list.New(),
+		b1:        list.New(),
+		b2:        list.New(),
+		nodes:     make(map[string]*list.Element),
+		OnEvicted: onEvicted,
+	}
+}
+
+func (a *ARCCache) listFor(kind arcListKind) *list.List {
+	switch kind {
+	case arcT1:
+		return a.t1
+	case arcT2:
+		return a.t2
+	case arcB1:
+		return a.b1
+	default:
+		return a.b2
+	}
+}
+
+// c 是论文里 T1+T2 的总容量，这里取当前 T1+T2 的条目数作为近似（见类型注释）
+func (a *ARCCache) c() int {
+	c := a.t1.Len() + a.t2.Len()
+	if c == 0 {
+		c = 1 // 避免第一次插入时 p 的 clamp 区间退化成 [0,0]
+	}
+	return c
+}
+
+// Get 查找键对应的值。命中时把条目提升到 T2 的 MRU 端（无论它来自 T1 还是 T2）
+func (a *ARCCache) Get(key string) (value Value, ok bool) {
+	ele, ok := a.nodes[key]
+	if !ok {
+		return nil, false
+	}
+	node := ele.Value.(*arcNode)
+	if node.list != arcT1 && node.list != arcT2 {
+		return nil, false // 只在幽灵链表里，等于未命中
+	}
+	a.listFor(node.list).Remove(ele)
+	node.list = arcT2
+	a.nodes[key] = a.t2.PushFront(node)
+	return node.value, true
+}
+
+// replace 按 ARC 的规则从 T1 或 T2 淘汰一个条目到对应的幽灵链表。
+// “偏好”的那条链表（由 p 和 keyInB2 决定）如果恰好是空的，就退回去淘汰另一条非空的链表，
+// 否则在 p 偏向一侧、而条目全挤在另一侧时会一直选中空链表、什么都没淘汰掉。
+func (a *ARCCache) replace(keyInB2 bool) {
+	evictFromT1 := a.t1.Len() >= 1 && (a.t1.Len() > a.p || (a.t1.Len() == a.p && keyInB2))
+	if !evictFromT1 && a.t2.Len() == 0 {
+		evictFromT1 = a.t1.Len() > 0
+	}
+	if evictFromT1 {
+		ele := a.t1.Back()
+		if ele == nil {
+			return
+		}
+		a.t1.Remove(ele)
+		node := ele.Value.(*arcNode)
+		a.nbytes -= len(node.key) + node.value.Len()
+		if a.OnEvicted != nil {
+			a.OnEvicted(node.key, node.value)
+		}
+		node.value = nil
+		node.list = arcB1
+		a.nodes[node.key] = a.b1.PushFront(node)
+	} else {
+		ele := a.t2.Back()
+		if ele == nil {
+			return
+		}
+		a.t2.Remove(ele)
+		node := ele.Value.(*arcNode)
+		a.nbytes -= len(node.key) + node.value.Len()
+		if a.OnEvicted != nil {
+			a.OnEvicted(node.key, node.value)
+		}
+		node.value = nil
+		node.list = arcB2
+		a.nodes[node.key] = a.b2.PushFront(node)
+	}
+}
+
+// trimGhost 把幽灵链表裁剪到不超过 limit 个条目，幽灵记录被丢弃时不触发 OnEvicted（它们本来就不持有 value）
+func (a *ARCCache) trimGhost(kind arcListKind, limit int) {
+	l := a.listFor(kind)
+	for l.Len() > limit {
+		ele := l.Back()
+		l.Remove(ele)
+		delete(a.nodes, ele.Value.(*arcNode).key)
+	}
+}
+
+// Add 新增或更新缓存
+func (a *ARCCache) Add(key string, value Value) {
+	ele, exists := a.nodes[key]
+	if !exists {
+		// case IV：全新的 key，既不在 T1/T2 也不在 B1/B2
+		a.replaceIfNeeded(false)
+		node := &arcNode{key: key, value: value, list: arcT1}
+		a.nodes[key] = a.t1.PushFront(node)
+		a.nbytes += len(key) + value.Len()
+		a.enforceByteBudget()
+		return
+	}
+
+	node := ele.Value.(*arcNode)
+	switch node.list {
+	case arcT1, arcT2:
+		// 已经在缓存里，更新值并提升到 T2 MRU
+		a.nbytes += value.Len() - node.value.Len()
+		node.value = value
+		a.listFor(node.list).Remove(ele)
+		node.list = arcT2
+		a.nodes[key] = a.t2.PushFront(node)
+	case arcB1:
+		// case I：命中 B1 的幽灵记录，说明最近淘汰得太快，向“近期性”倾斜
+		delta := a.b2.Len() / maxInt(a.b1.Len(), 1)
+		a.p = minInt(a.c(), a.p+maxInt(delta, 1))
+		a.b1.Remove(ele)
+		a.replace(false)
+		node.value = value
+		node.list = arcT2
+		a.nodes[key] = a.t2.PushFront(node)
+		a.nbytes += len(key) + value.Len()
+	case arcB2:
+		// case II：命中 B2 的幽灵记录，说明最近淘汰的频繁项太多，向“频率”倾斜
+		delta := a.b1.Len() / maxInt(a.b2.Len(), 1)
+		a.p = maxInt(0, a.p-maxInt(delta, 1))
+		a.b2.Remove(ele)
+		a.replace(true)
+		node.value = value
+		node.list = arcT2
+		a.nodes[key] = a.t2.PushFront(node)
+		a.nbytes += len(key) + value.Len()
+	}
+	a.enforceByteBudget()
+}
+
+// replaceIfNeeded 对应论文 case IV 里先腾出空间的部分：如果幽灵链表也满了就丢弃它的 LRU 端，
+// 只有在 T1+T2 已经顶到当前容量时才真正淘汰一个有值的条目
+func (a *ARCCache) replaceIfNeeded(keyInB2 bool) {
+	c := a.c()
+	if a.t1.Len()+a.b1.Len() >= c {
+		if a.t1.Len() < c {
+			a.trimGhost(arcB1, maxInt(a.b1.Len()-1, 0))
+			a.replace(keyInB2)
+		} else if ele := a.t1.Back(); ele != nil {
+			a.t1.Remove(ele)
+			node := ele.Value.(*arcNode)
+			a.nbytes -= len(node.key) + node.value.Len()
+			if a.OnEvicted != nil {
+				a.OnEvicted(node.key, node.value)
+			}
+			delete(a.nodes, node.key)
+		}
+	} else if a.t1.Len()+a.t2.Len()+a.b1.Len()+a.b2.Len() >= 2*c {
+		a.trimGhost(arcB2, maxInt(a.b2.Len()-1, 0))
+	}
+}
+
+// enforceByteBudget 是真正保证 nbytes<=maxBytes 的地方：不断按 ARC 的规则淘汰，直到字节预算满足为止
+func (a *ARCCache) enforceByteBudget() {
+	for a.maxBytes != 0 && a.nbytes > a.maxBytes && (a.t1.Len() > 0 || a.t2.Len() > 0) {
+		a.replace(false)
+	}
+}
+
+// Remove 主动移除一个 key（T1/T2 中的，不含幽灵记录），key 不存在时返回 false
+func (a *ARCCache) Remove(key string) bool {
+	ele, ok := a.nodes[key]
+	if !ok {
+		return false
+	}
+	node := ele.Value.(*arcNode)
+	if node.list != arcT1 && node.list != arcT2 {
+		return false
+	}
+	a.listFor(node.list).Remove(ele)
+	delete(a.nodes, key)
+	a.nbytes -= len(key) + node.value.Len()
+	if a.OnEvicted != nil {
+		a.OnEvicted(key, node.value)
+	}
+	return true
+}
+
+// Evict 按 ARC 规则淘汰一个条目
+func (a *ARCCache) Evict() {
+	a.replace(false)
+}
+
+// Len 返回 T1+T2 中持有实际值的条目数（不含幽灵记录）
+func (a *ARCCache) Len() int {
+	return a.t1.Len() + a.t2.Len()
+}
+
+// Bytes 返回当前已使用的内存
+func (a *ARCCache) Bytes() int {
+	return a.nbytes
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+var _ Policy = (*ARCCache)(nil)
@@ -0,0 +1,94 @@
+package lru
+
+import (
+	"container/list"
+)
+
+type fifoEntry struct {
+	key   string
+	value Value
+}
+
+// FIFOCache 是先进先出的淘汰策略：命中不会改变条目的顺序，
+// 超出 maxBytes 时总是淘汰最早插入、且仍然存在的那个条目。
+type FIFOCache struct {
+	maxBytes  int
+	nbytes    int
+	l1        *list.List
+	cache     map[string]*list.Element
+	OnEvicted func(key string, value Value)
+}
+
+// NewFIFO 创建一个 FIFO 策略的缓存
+func NewFIFO(maxBytes int, onEvicted func(string, Value)) *FIFOCache {
+	return &FIFOCache{
+		maxBytes:  maxBytes,
+		l1:        list.New(),
+		cache:     make(map[string]*list.Element),
+		OnEvicted: onEvicted,
+	}
+}
+
+// Get 查找键对应的值，FIFO 不关心访问顺序，所以命中不会移动节点
+func (c *FIFOCache) Get(key string) (value Value, ok bool) {
+	if ele, ok := c.cache[key]; ok {
+		kv := ele.Value.(*fifoEntry)
+		return kv.value, true
+	}
+	return
+}
+
+// Evict 淘汰最早插入的条目（队首）
+func (c *FIFOCache) Evict() {
+	ele := c.l1.Back()
+	if ele != nil {
+		c.removeElement(ele)
+	}
+}
+
+// Remove 主动移除一个 key，key 不存在时返回 false
+func (c *FIFOCache) Remove(key string) bool {
+	if ele, ok := c.cache[key]; ok {
+		c.removeElement(ele)
+		return true
+	}
+	return false
+}
+
+func (c *FIFOCache) removeElement(ele *list.Element) {
+	c.l1.Remove(ele)
+	kv := ele.Value.(*fifoEntry)
+	delete(c.cache, kv.key)
+	c.nbytes -= len(kv.key) + kv.value.Len()
+	if c.OnEvicted != nil {
+		c.OnEvicted(kv.key, kv.value)
+	}
+}
+
+// Add 新增或更新缓存。更新已有 key 只替换值，不改变它在队列中的位置
+func (c *FIFOCache) Add(key string, value Value) {
+	if ele, ok := c.cache[key]; ok {
+		kv := ele.Value.(*fifoEntry)
+		c.nbytes += value.Len() - kv.value.Len()
+		kv.value = value
+	} else {
+		ele := c.l1.PushFront(&fifoEntry{key, value})
+		c.cache[key] = ele
+		c.nbytes += len(key) + value.Len()
+	}
+	for c.maxBytes != 0 && c.maxBytes < c.nbytes {
+		c.Evict()
+	}
+}
+
+// Len 返回缓存条目数
+func (c *FIFOCache) Len() int {
+	return c.l1.Len()
+}
+
+// Bytes 返回当前已使用的内存
+func (c *FIFOCache) Bytes() int {
+	return c.nbytes
+}
+
+var _ Policy = (*FIFOCache)(nil)
@@ -0,0 +1,148 @@
+package lru
+
+import (
+	"container/list"
+)
+
+type lfuEntry struct {
+	key   string
+	value Value
+	freq  int
+}
+
+// LFUCache 是最不经常使用的淘汰策略，经典的 O(1) 实现：
+// 把访问频次相同的条目各自挂在一条双向链表上（freqList[freq]），
+// 同一条链表内部按 LRU 顺序维护，这样同频次下淘汰的仍然是最久未访问的那个。
+type LFUCache struct {
+	maxBytes  int
+	nbytes    int
+	minFreq   int
+	cache     map[string]*list.Element // key -> 节点，节点挂在 freqList[freq] 上
+	freqList  map[int]*list.List
+	OnEvicted func(key string, value Value)
+}
+
+// NewLFU 创建一个 LFU 策略的缓存
+func NewLFU(maxBytes int, onEvicted func(string, Value)) *LFUCache {
+	return &LFUCache{
+		maxBytes:  maxBytes,
+		cache:     make(map[string]*list.Element),
+		freqList:  make(map[int]*list.List),
+		OnEvicted: onEvicted,
+	}
+}
+
+// touch 把 key 对应的频次加一，并把节点从旧的频次链表搬到新的频次链表队首
+func (c *LFUCache) touch(ele *list.Element) {
+	kv := ele.Value.(*lfuEntry)
+	oldFreq := kv.freq
+	c.freqList[oldFreq].Remove(ele)
+	if c.freqList[oldFreq].Len() == 0 {
+		delete(c.freqList, oldFreq)
+		if c.minFreq == oldFreq {
+			c.minFreq++
+		}
+	}
+	kv.freq++
+	if c.freqList[kv.freq] == nil {
+		c.freqList[kv.freq] = list.New()
+	}
+	c.cache[kv.key] = c.freqList[kv.freq].PushFront(kv)
+}
+
+// Get 查找键对应的值，命中会让它的访问频次加一
+func (c *LFUCache) Get(key string) (value Value, ok bool) {
+	if ele, ok := c.cache[key]; ok {
+		kv := ele.Value.(*lfuEntry)
+		c.touch(ele)
+		return kv.value, true
+	}
+	return
+}
+
+// Evict 淘汰当前最小频次链表队尾的条目（该频次下最久未被访问的条目）
+func (c *LFUCache) Evict() {
+	l := c.freqList[c.minFreq]
+	if l == nil {
+		return
+	}
+	ele := l.Back()
+	if ele == nil {
+		return
+	}
+	c.removeElement(ele)
+	if l.Len() == 0 {
+		delete(c.freqList, c.minFreq)
+		c.minFreq = c.nextMinFreq() // 淘汰后清空了 minFreq 那一档，需要重新找最小的非空频次
+	}
+}
+
+// Remove 主动移除一个 key，key 不存在时返回 false
+func (c *LFUCache) Remove(key string) bool {
+	if ele, ok := c.cache[key]; ok {
+		freq := ele.Value.(*lfuEntry).freq
+		c.removeElement(ele)
+		if l := c.freqList[freq]; l != nil && l.Len() == 0 {
+			delete(c.freqList, freq)
+			if freq == c.minFreq {
+				c.minFreq = c.nextMinFreq() // 清空的恰好是 minFreq 那一档，需要重新找最小的非空频次
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// nextMinFreq 扫描 freqList 找出当前最小的非空频次，缓存为空时返回 0
+func (c *LFUCache) nextMinFreq() int {
+	min := 0
+	for freq := range c.freqList {
+		if min == 0 || freq < min {
+			min = freq
+		}
+	}
+	return min
+}
+
+func (c *LFUCache) removeElement(ele *list.Element) {
+	kv := ele.Value.(*lfuEntry)
+	c.freqList[kv.freq].Remove(ele)
+	delete(c.cache, kv.key)
+	c.nbytes -= len(kv.key) + kv.value.Len()
+	if c.OnEvicted != nil {
+		c.OnEvicted(kv.key, kv.value)
+	}
+}
+
+// Add 新增或更新缓存。新条目从频次 1 开始计数
+func (c *LFUCache) Add(key string, value Value) {
+	if ele, ok := c.cache[key]; ok {
+		kv := ele.Value.(*lfuEntry)
+		c.nbytes += value.Len() - kv.value.Len()
+		kv.value = value
+		c.touch(ele)
+	} else {
+		kv := &lfuEntry{key: key, value: value, freq: 1}
+		if c.freqList[1] == nil {
+			c.freqList[1] = list.New()
+		}
+		c.cache[key] = c.freqList[1].PushFront(kv)
+		c.nbytes += len(key) + value.Len()
+		c.minFreq = 1
+	}
+	for c.maxBytes != 0 && c.maxBytes < c.nbytes {
+		c.Evict()
+	}
+}
+
+// Len 返回缓存条目数
+func (c *LFUCache) Len() int {
+	return len(c.cache)
+}
+
+// Bytes 返回当前已使用的内存
+func (c *LFUCache) Bytes() int {
+	return c.nbytes
+}
+
+var _ Policy = (*LFUCache)(nil)
@@ -0,0 +1,55 @@
+package lru
+
+import "testing"
+
+// TestLFURemoveAdvancesMinFreq 回归测试：Remove 清空 minFreq 所在的频次桶之后，
+// minFreq 必须跟着前进，否则后续 Evict 会一直在一个空桶上找不到条目可淘汰。
+func TestLFURemoveAdvancesMinFreq(t *testing.T) {
+	c := NewLFU(0, nil)
+	c.Add("a", stringValue("1"))
+	c.Add("b", stringValue("2"))
+	c.Get("b") // b 的频次变成 2，a 仍然是频次 1 的唯一条目
+
+	c.Remove("a") // 清空频次 1 这个桶，此时它正好是 c.minFreq
+
+	if c.minFreq != 2 {
+		t.Fatalf("minFreq = %d, want 2 after emptying the bucket at the old minFreq", c.minFreq)
+	}
+
+	c.Evict() // 必须淘汰 b，而不是在空桶上静默放弃
+	if c.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0 after Evict", c.Len())
+	}
+}
+
+// TestLFUEvictOnUpdateDoesNotLivelock 复现 review 里的具体场景：Add("a")、Add("b")、
+// Get("b")、Remove("a") 之后 minFreq 所在的桶被清空，遗留的 bug 会让它卡在旧值上，
+// 此时更新一个已存在的 key 使其超过 maxBytes 会让 Add 的驱逐循环永远找不到条目可淘汰。
+func TestLFUEvictOnUpdateDoesNotLivelock(t *testing.T) {
+	c := NewLFU(10, nil)
+	c.Add("a", stringValue("1234")) // nbytes = 5
+	c.Add("b", stringValue("1234")) // nbytes = 10，恰好不超过 maxBytes，两个条目都留在缓存里
+	c.Get("b")
+	c.Remove("a") // 清空频次 1 的桶，它正好是 c.minFreq
+
+	c.Add("b", stringValue("0123456789")) // 更新现有 key，超过 maxBytes，需要驱逐才能继续而不是卡住
+	if c.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0 (the only entry had to be evicted to get back under maxBytes)", c.Len())
+	}
+}
+
+// TestLFUEvictAdvancesMinFreq 回归测试：minFreq 所在的桶被 Evict 自己清空时（而不是 Remove），
+// Evict 也必须前进 minFreq，否则后续的驱逐会一直在一个空桶上找不到条目，永远淘汰不动。
+func TestLFUEvictAdvancesMinFreq(t *testing.T) {
+	c := NewLFU(10, nil)
+	c.Add("p", stringValue("pp")) // freq 1, nbytes = 3
+	c.Get("p")                    // freq 2, nbytes 不变
+	c.Add("z", stringValue("z"))  // 新 key，freq 1，nbytes = 5
+
+	// 更新已存在的 p（freq>=2），使 nbytes 超过 maxBytes 而不经过频次 1 的桶
+	c.Add("p", stringValue("01234567890123456789"))
+
+	if c.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0 (everything had to be evicted to get back under maxBytes)", c.Len())
+	}
+}
@@ -2,6 +2,10 @@ package lru
 
 import (
 	"container/list"
+	"log"
+	"os"
+	"sync/atomic"
+	"time"
 )
 
 // 为了通用性，值可以是实现了 Value 接口的任意类型，
@@ -10,17 +14,37 @@ type Value interface {
 	Len() int
 }
 
+// EvictionReason 描述一个条目离开缓存的原因，供 OnEvictedReason 回调区分
+type EvictionReason int
+
+const (
+	ReasonCapacity EvictionReason = iota // 因超出 maxBytes 被 LRU 淘汰
+	ReasonExpired                        // TTL 到期被清理
+	ReasonManual                         // 被调用方手动移除
+)
+
 type entry struct {
-	key   string
-	value Value
+	key      string
+	value    Value
+	expireAt time.Time // 零值表示没有设置过期时间
+}
+
+// expired 判断该条目是否已经过期
+func (e *entry) expired(now time.Time) bool {
+	return !e.expireAt.IsZero() && !now.Before(e.expireAt)
 }
 
 type Cache struct { // LRU cache
-	maxBytes  int                           // maxBytes 是允许使用的最大内存
-	nbytes    int                           // nbytes 是当前已使用的内存
-	l1        *list.List                    //	基于双向链表实现的缓存
-	cache     map[string]*list.Element      // 键是字符串，值是双向链表中对应节点的指针。
-	OnEvicted func(key string, value Value) // OnEvicted 是某条记录被移除时的回调函数，可以为 nil
+	maxBytes        int                                                  // maxBytes 是允许使用的最大内存
+	nbytes          int                                                  // nbytes 是当前已使用的内存
+	l1              *list.List                                           //	基于双向链表实现的缓存
+	cache           map[string]*list.Element                             // 键是字符串，值是双向链表中对应节点的指针。
+	OnEvicted       func(key string, value Value)                        // OnEvicted 是某条记录被移除时的回调函数，可以为 nil
+	OnEvictedReason func(key string, value Value, reason EvictionReason) // 与 OnEvicted 并行触发，额外携带淘汰原因
+
+	wal *os.File // 非 nil 时，EnableWAL 打开的预写日志文件，每次 Add/RemoveOldest 都会追加一条记录
+
+	hits, misses, evictions, adds, updates uint64 // 统计计数器，通过 sync/atomic 操作，详见 Stats
 }
 
 // Constructor of Cache
@@ -34,48 +58,172 @@ func New(maxBytes int, onEvicted func(string, Value)) *Cache {
 }
 
 // Get value(use key)
-//第一步是从字典中找到对应的双向链表的节点，第二步，将该节点移动到队尾
+// 第一步是从字典中找到对应的双向链表的节点，第二步，将该节点移动到队尾
 func (c *Cache) Get(key string) (value Value, ok bool) {
 	if ele, ok := c.cache[key]; ok {
-		c.l1.MoveToFront(ele)    // 移动到队尾
 		kv := ele.Value.(*entry) // 取出节点的值
-		return kv.value, true    // 返回值和是否找到的标志
+		if kv.expired(time.Now()) {
+			c.removeElement(ele, ReasonExpired) // 已过期，当作未命中并顺手清理
+			atomic.AddUint64(&c.misses, 1)
+			return nil, false
+		}
+		c.l1.MoveToFront(ele) // 移动到队尾
+		atomic.AddUint64(&c.hits, 1)
+		return kv.value, true // 返回值和是否找到的标志
 	}
+	atomic.AddUint64(&c.misses, 1)
 	return
 }
 
+// Peek 返回 key 对应的值但不把它移动到队尾，也不计入命中/未命中统计，
+// 适合在准入策略实验或指标采集时读取缓存而不影响其 LRU 顺序。
+func (c *Cache) Peek(key string) (value Value, ok bool) {
+	if ele, ok := c.cache[key]; ok {
+		kv := ele.Value.(*entry)
+		if kv.expired(time.Now()) {
+			return nil, false
+		}
+		return kv.value, true
+	}
+	return
+}
+
+// Range 按从最近使用到最久未使用（MRU -> LRU）的顺序遍历缓存条目，不改变它们的顺序。
+// fn 返回 false 时提前终止遍历。
+func (c *Cache) Range(fn func(key string, value Value) bool) {
+	for ele := c.l1.Front(); ele != nil; ele = ele.Next() {
+		kv := ele.Value.(*entry)
+		if !fn(kv.key, kv.value) {
+			return
+		}
+	}
+}
+
 // 移除最近最少访问的节点（队首）,即缓存淘汰
 func (c *Cache) RemoveOldest() {
 	ele := c.l1.Back() //取到队首节点
 	if ele != nil {
-		c.l1.Remove(ele) // 删除队首节点
-		kv := ele.Value.(*entry)
-		delete(c.cache, kv.key)                  //从字典中 c.cache 删除该节点的映射关系。
-		c.nbytes -= len(kv.key) + kv.value.Len() // 删除该节点后，更新内存使用量
-		if c.OnEvicted != nil {
-			c.OnEvicted(kv.key, kv.value) // 如果有回调函数（不为nil），则调用回调函数
+		key := ele.Value.(*entry).key
+		c.removeElement(ele, ReasonCapacity)
+		if c.wal != nil {
+			c.appendWAL(walOpRemove, key, nil)
 		}
 	}
 }
 
+// removeElement 是真正执行移除的地方：从链表和字典中删除节点、更新内存使用量，并触发回调
+func (c *Cache) removeElement(ele *list.Element, reason EvictionReason) {
+	c.l1.Remove(ele) // 删除节点
+	kv := ele.Value.(*entry)
+	delete(c.cache, kv.key)                  //从字典中 c.cache 删除该节点的映射关系。
+	c.nbytes -= len(kv.key) + kv.value.Len() // 删除该节点后，更新内存使用量
+	if reason != ReasonManual {
+		atomic.AddUint64(&c.evictions, 1) // 主动 Remove 不算淘汰，只统计容量/过期触发的自动清理
+	}
+	if c.OnEvicted != nil {
+		c.OnEvicted(kv.key, kv.value) // 如果有回调函数（不为nil），则调用回调函数
+	}
+	if c.OnEvictedReason != nil {
+		c.OnEvictedReason(kv.key, kv.value, reason)
+	}
+}
+
 // 新增或更新缓存
 func (c *Cache) Add(key string, value Value) {
+	c.addEntry(key, value, time.Time{})
+}
+
+// AddWithTTL 新增或更新缓存，并在 ttl 之后让该条目过期
+func (c *Cache) AddWithTTL(key string, value Value, ttl time.Duration) {
+	c.addEntry(key, value, time.Now().Add(ttl))
+}
+
+func (c *Cache) addEntry(key string, value Value, expireAt time.Time) {
 	if ele, ok := c.cache[key]; ok { //如果键存在，则更新对应节点的值，并将该节点移到队尾。
 		c.l1.MoveToFront(ele)                    // 移动到队尾
 		kv := ele.Value.(*entry)                 // 取出节点的值
 		c.nbytes += value.Len() - kv.value.Len() // 更新内存使用量
 		kv.value = value
+		kv.expireAt = expireAt
+		atomic.AddUint64(&c.updates, 1)
 	} else {
-		ele := c.l1.PushFront(&entry{key, value}) // 如果键不存在，则新增节点，并将该节点移到队尾。
+		ele := c.l1.PushFront(&entry{key, value, expireAt}) // 如果键不存在，则新增节点，并将该节点移到队尾。
 		c.cache[key] = ele
 		c.nbytes += len(key) + value.Len()
+		atomic.AddUint64(&c.adds, 1)
+	}
+	if c.wal != nil {
+		if raw, ok := marshalForWAL(value); ok {
+			c.appendWAL(walOpAdd, key, raw)
+		} else {
+			log.Printf("lru: WAL: key %q skipped, value does not implement Marshaler or failed to marshal", key)
+		}
 	}
 	for c.maxBytes != 0 && c.maxBytes < c.nbytes { // 如果内存使用量超过了最大值，则移除最近最少访问的节点。
 		c.RemoveOldest()
 	}
 }
 
+// StartJanitor 启动一个后台 goroutine，每隔 interval 从队首（最久未访问的一端）向前扫描并清理已过期的条目。
+// 返回的 stop 函数用于停止该 goroutine。注意这本身就会引入第二个 goroutine 访问 Cache，
+// 所以只要业务代码在启动之后还会从任意 goroutine（哪怕只有一个）调用 Get/Add 等方法，
+// 就已经和这里的后台清理构成数据竞争。需要在调用方存在的同时做 TTL 清理时，
+// 请使用 SyncCache.StartJanitor，它和 Get/AddWithTTL 共用同一把锁。
+func (c *Cache) StartJanitor(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				c.sweepExpired()
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+	}
+}
+
+// sweepExpired 从队首开始清理已经过期的条目，遇到未过期的节点会跳过并继续向前
+// （因为队首是最久未被访问的节点，不代表一定最先过期，所以这里遍历整条链表而不是提前退出）
+func (c *Cache) sweepExpired() {
+	now := time.Now()
+	for ele := c.l1.Back(); ele != nil; {
+		prev := ele.Prev()
+		kv := ele.Value.(*entry)
+		if kv.expired(now) {
+			c.removeElement(ele, ReasonExpired)
+		}
+		ele = prev
+	}
+}
+
 // 获取缓存条目数
 func (c *Cache) Len() int {
 	return c.l1.Len()
 }
+
+// Remove 主动移除一个 key，key 不存在时返回 false
+func (c *Cache) Remove(key string) bool {
+	if ele, ok := c.cache[key]; ok {
+		c.removeElement(ele, ReasonManual)
+		return true
+	}
+	return false
+}
+
+// Evict 按 LRU 顺序淘汰一个条目，是 RemoveOldest 在 Policy 接口下的别名
+func (c *Cache) Evict() {
+	c.RemoveOldest()
+}
+
+// Bytes 返回当前已使用的内存
+func (c *Cache) Bytes() int {
+	return c.nbytes
+}
+
+var _ Policy = (*Cache)(nil)
@@ -0,0 +1,151 @@
+package lru
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+)
+
+// snapshotMagic 是快照文件的魔数，用来在 Restore 时快速识别格式是否匹配
+const snapshotMagic = "LRU1"
+
+// Marshaler 是值在被持久化（Snapshot/WAL）之前需要实现的可选接口。
+// 没有实现它的值在快照里会被跳过，并打印一条警告。
+type Marshaler interface {
+	MarshalBinary() ([]byte, error)
+}
+
+// Snapshot 把缓存当前的全部条目按 LRU 顺序（队首/最久未访问的在前）写入 w，
+// 这样 Restore 时依次 Add 就能还原出原来的访问顺序。
+// 每条记录是 长度前缀的 key + 长度前缀的 value + CRC32 校验和；value 取自 MarshalBinary()。
+func (c *Cache) Snapshot(w io.Writer) error {
+	if _, err := io.WriteString(w, snapshotMagic); err != nil {
+		return err
+	}
+	for ele := c.l1.Back(); ele != nil; ele = ele.Prev() {
+		kv := ele.Value.(*entry)
+		m, ok := kv.value.(Marshaler)
+		if !ok {
+			log.Printf("lru: Snapshot: key %q skipped, value does not implement Marshaler", kv.key)
+			continue
+		}
+		raw, err := m.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("lru: Snapshot: marshal key %q: %w", kv.key, err)
+		}
+		if err := writeRecord(w, kv.key, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore 从 r 中读取一份由 Snapshot 写出的快照，依次调用 newValue 把原始字节还原成 Value，
+// 重建一个新的 Cache。maxBytes 的语义和 New 完全一致（0 表示不限制），由调用方显式指定，
+// 不会根据快照里恢复出多少字节自行推断——快照为空时这样才不会意外变成一个无限容量的缓存。
+func Restore(r io.Reader, maxBytes int, newValue func(key string, raw []byte) Value, onEvicted func(string, Value)) (*Cache, error) {
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, fmt.Errorf("lru: Restore: read header: %w", err)
+	}
+	if string(magic) != snapshotMagic {
+		return nil, fmt.Errorf("lru: Restore: bad magic header %q", magic)
+	}
+	c := New(maxBytes, onEvicted)
+	for {
+		key, raw, err := readRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		c.Add(key, newValue(key, raw))
+	}
+	return c, nil
+}
+
+// writeRecord 写出一条 长度前缀(key) + 长度前缀(value) + CRC32 记录
+func writeRecord(w io.Writer, key string, raw []byte) error {
+	buf := encodeRecord(key, raw)
+	crc := crc32.ChecksumIEEE(buf)
+	if err := binary.Write(w, binary.BigEndian, uint32(len(buf))); err != nil {
+		return err
+	}
+	if _, err := w.Write(buf); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.BigEndian, crc)
+}
+
+// readRecord 读取一条由 writeRecord 写出的记录并校验 CRC32
+func readRecord(r io.Reader) (key string, raw []byte, err error) {
+	var size uint32
+	if err = binary.Read(r, binary.BigEndian, &size); err != nil {
+		return
+	}
+	buf := make([]byte, size)
+	if _, err = io.ReadFull(r, buf); err != nil {
+		return
+	}
+	var wantCRC uint32
+	if err = binary.Read(r, binary.BigEndian, &wantCRC); err != nil {
+		return
+	}
+	if crc32.ChecksumIEEE(buf) != wantCRC {
+		err = fmt.Errorf("lru: corrupt record: crc32 mismatch")
+		return
+	}
+	key, raw, err = decodeRecord(buf)
+	return
+}
+
+// encodeRecord/decodeRecord 约定 key 和 value 各自的长度前缀编码，供快照和 WAL 共用
+func encodeRecord(key string, raw []byte) []byte {
+	buf := make([]byte, 0, 8+len(key)+len(raw))
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(key)))
+	buf = append(buf, lenBuf[:]...)
+	buf = append(buf, key...)
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(raw)))
+	buf = append(buf, lenBuf[:]...)
+	buf = append(buf, raw...)
+	return buf
+}
+
+func decodeRecord(buf []byte) (key string, raw []byte, err error) {
+	if len(buf) < 4 {
+		return "", nil, fmt.Errorf("lru: corrupt record: too short")
+	}
+	klen := binary.BigEndian.Uint32(buf[0:4])
+	buf = buf[4:]
+	if uint32(len(buf)) < klen+4 {
+		return "", nil, fmt.Errorf("lru: corrupt record: truncated key")
+	}
+	key = string(buf[:klen])
+	buf = buf[klen:]
+	vlen := binary.BigEndian.Uint32(buf[0:4])
+	buf = buf[4:]
+	if uint32(len(buf)) < vlen {
+		return "", nil, fmt.Errorf("lru: corrupt record: truncated value")
+	}
+	raw = buf[:vlen]
+	return key, raw, nil
+}
+
+// marshalForWAL 尝试把 value 序列化成可以写入 WAL 的字节。value 没有实现 Marshaler 时直接返回 ok=false
+// （警告由调用方打印）；MarshalBinary 出错时打印警告并返回 ok=false
+func marshalForWAL(value Value) (raw []byte, ok bool) {
+	m, ok := value.(Marshaler)
+	if !ok {
+		return nil, false
+	}
+	raw, err := m.MarshalBinary()
+	if err != nil {
+		log.Printf("lru: WAL: marshal value failed: %v", err)
+		return nil, false
+	}
+	return raw, true
+}
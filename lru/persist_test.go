@@ -0,0 +1,78 @@
+package lru
+
+import (
+	"bytes"
+	"testing"
+)
+
+// stringValue 是测试里用到的最简单的 Value 实现，同时实现 Marshaler 以便测试 Snapshot/WAL
+type stringValue string
+
+func (s stringValue) Len() int { return len(s) }
+
+func (s stringValue) MarshalBinary() ([]byte, error) { return []byte(s), nil }
+
+func newStringValue(key string, raw []byte) Value { return stringValue(raw) }
+
+func TestSnapshotRestoreRoundTrip(t *testing.T) {
+	c := New(0, nil)
+	c.Add("a", stringValue("1"))
+	c.Add("b", stringValue("2"))
+	c.Add("c", stringValue("3"))
+	c.Get("a") // 让 a 变成最近访问过的；此时 LRU 顺序（MRU->LRU）为 a, c, b
+
+	var buf bytes.Buffer
+	if err := c.Snapshot(&buf); err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+
+	restored, err := Restore(&buf, 0, newStringValue, nil)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if restored.Len() != 3 {
+		t.Fatalf("restored.Len() = %d, want 3", restored.Len())
+	}
+
+	// Restore 依次 Add 读出的记录（队首/最久未访问的在前），恢复后最久未访问的应当还是 b；
+	// 在 Get 之前检查，因为 Get 本身会把条目移动到队尾扰乱顺序
+	oldest := restored.l1.Back().Value.(*entry).key
+	if oldest != "b" {
+		t.Fatalf("restored oldest key = %q, want %q", oldest, "b")
+	}
+
+	want := map[string]stringValue{"a": "1", "b": "2", "c": "3"}
+	for key, wantValue := range want {
+		got, ok := restored.Get(key)
+		if !ok {
+			t.Fatalf("restored missing key %q", key)
+		}
+		if got != wantValue {
+			t.Fatalf("restored[%q] = %v, want %v", key, got, wantValue)
+		}
+	}
+}
+
+func TestRestoreRejectsBadMagic(t *testing.T) {
+	_, err := Restore(bytes.NewReader([]byte("nope")), 0, newStringValue, nil)
+	if err == nil {
+		t.Fatal("Restore: want error for bad magic header, got nil")
+	}
+}
+
+// TestRestoreEmptySnapshotDoesNotBecomeUnlimited 回归测试：恢复一份不含任何记录的快照
+// （nbytes 恰好是 0）不能因此静默变成无限容量的缓存，maxBytes 必须是调用方显式传入的值。
+func TestRestoreEmptySnapshotDoesNotBecomeUnlimited(t *testing.T) {
+	var buf bytes.Buffer
+	if err := New(0, nil).Snapshot(&buf); err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+
+	restored, err := Restore(&buf, 5, newStringValue, nil)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if restored.maxBytes != 5 {
+		t.Fatalf("restored.maxBytes = %d, want 5, got silently-unlimited cache from an empty snapshot", restored.maxBytes)
+	}
+}
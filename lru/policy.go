@@ -0,0 +1,38 @@
+package lru
+
+// Policy 定义了一种缓存淘汰策略必须具备的能力。
+// Cache（LRU）、FIFOCache、LFUCache、TinyLFUCache 都实现了该接口，
+// 调用方可以通过 NewCache 按 Kind 选择其中一种，而不需要关心具体的淘汰算法，
+// 也不需要改动 Value 接口或 OnEvicted 回调的用法。
+type Policy interface {
+	Get(key string) (value Value, ok bool)
+	Add(key string, value Value)
+	Remove(key string) bool // 主动移除一个 key，key 不存在时返回 false
+	Evict()                 // 按策略淘汰一个条目
+	Len() int
+	Bytes() int
+}
+
+// Kind 标识 NewCache 应该构造哪一种淘汰策略
+type Kind int
+
+const (
+	KindLRU     Kind = iota // 最近最少使用，适合访问模式比较均衡的场景
+	KindFIFO                // 先进先出，不关心访问热度，开销最低
+	KindLFU                 // 最不经常使用，适合存在稳定热点的场景
+	KindTinyLFU             // 在 LRU 之上加一层基于 count-min sketch 的准入过滤，兼顾扫描抵抗能力
+)
+
+// NewCache 按 kind 构造对应的 Policy 实现，maxBytes 和 onEvicted 的语义与 New 保持一致
+func NewCache(kind Kind, maxBytes int, onEvicted func(string, Value)) Policy {
+	switch kind {
+	case KindFIFO:
+		return NewFIFO(maxBytes, onEvicted)
+	case KindLFU:
+		return NewLFU(maxBytes, onEvicted)
+	case KindTinyLFU:
+		return NewTinyLFU(maxBytes, onEvicted)
+	default:
+		return New(maxBytes, onEvicted)
+	}
+}
@@ -0,0 +1,31 @@
+package lru
+
+import "sync/atomic"
+
+// Stats 是某一时刻 Cache 计数器的快照，供监控/导出使用
+type Stats struct {
+	Hits, Misses, Evictions, Adds, Updates uint64
+	Bytes, MaxBytes                        int
+}
+
+// Stats 返回当前的统计快照，计数器部分通过 sync/atomic 读取
+func (c *Cache) Stats() Stats {
+	return Stats{
+		Hits:      atomic.LoadUint64(&c.hits),
+		Misses:    atomic.LoadUint64(&c.misses),
+		Evictions: atomic.LoadUint64(&c.evictions),
+		Adds:      atomic.LoadUint64(&c.adds),
+		Updates:   atomic.LoadUint64(&c.updates),
+		Bytes:     c.nbytes,
+		MaxBytes:  c.maxBytes,
+	}
+}
+
+// ResetStats 把 Hits/Misses/Evictions/Adds/Updates 计数器清零，不影响缓存内容本身
+func (c *Cache) ResetStats() {
+	atomic.StoreUint64(&c.hits, 0)
+	atomic.StoreUint64(&c.misses, 0)
+	atomic.StoreUint64(&c.evictions, 0)
+	atomic.StoreUint64(&c.adds, 0)
+	atomic.StoreUint64(&c.updates, 0)
+}
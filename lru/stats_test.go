@@ -0,0 +1,108 @@
+package lru
+
+import "testing"
+
+func TestStatsCountsHitsMissesEvictionsAddsUpdates(t *testing.T) {
+	c := New(6, nil)
+	c.Add("a", stringValue("aa")) // add
+	c.Add("a", stringValue("a"))  // update
+	c.Get("a")                    // hit
+	c.Get("missing")              // miss
+	c.Add("b", stringValue("bb")) // add
+	c.Add("c", stringValue("cc")) // add，超过 maxBytes，淘汰一个条目
+
+	st := c.Stats()
+	if st.Adds != 3 {
+		t.Fatalf("Adds = %d, want 3", st.Adds)
+	}
+	if st.Updates != 1 {
+		t.Fatalf("Updates = %d, want 1", st.Updates)
+	}
+	if st.Hits != 1 {
+		t.Fatalf("Hits = %d, want 1", st.Hits)
+	}
+	if st.Misses != 1 {
+		t.Fatalf("Misses = %d, want 1", st.Misses)
+	}
+	if st.Evictions != 1 {
+		t.Fatalf("Evictions = %d, want 1", st.Evictions)
+	}
+	if st.Bytes != c.nbytes || st.MaxBytes != c.maxBytes {
+		t.Fatalf("Bytes/MaxBytes = %d/%d, want %d/%d", st.Bytes, st.MaxBytes, c.nbytes, c.maxBytes)
+	}
+
+	c.ResetStats()
+	st = c.Stats()
+	if st.Hits != 0 || st.Misses != 0 || st.Evictions != 0 || st.Adds != 0 || st.Updates != 0 {
+		t.Fatalf("Stats() after ResetStats = %+v, want all counters zero", st)
+	}
+	if c.Len() != 2 {
+		t.Fatalf("ResetStats should not touch cache contents, Len() = %d, want 2", c.Len())
+	}
+}
+
+func TestManualRemoveIsNotCountedAsEviction(t *testing.T) {
+	c := New(0, nil)
+	c.Add("a", stringValue("1"))
+	c.Remove("a")
+
+	if got := c.Stats().Evictions; got != 0 {
+		t.Fatalf("Evictions = %d, want 0 (manual Remove is not an eviction)", got)
+	}
+}
+
+func TestPeekDoesNotPromoteOrCountStats(t *testing.T) {
+	c := New(0, nil)
+	c.Add("a", stringValue("1"))
+	c.Add("b", stringValue("2"))
+	// LRU 顺序（MRU->LRU）现在是 b, a
+
+	if got, ok := c.Peek("a"); !ok || got != stringValue("1") {
+		t.Fatalf("Peek(a) = %v, %v", got, ok)
+	}
+
+	oldest := c.l1.Back().Value.(*entry).key
+	if oldest != "a" {
+		t.Fatalf("oldest = %q, want %q; Peek changed the LRU order", oldest, "a")
+	}
+	if st := c.Stats(); st.Hits != 0 || st.Misses != 0 {
+		t.Fatalf("Peek should not affect Hits/Misses, got Hits=%d Misses=%d", st.Hits, st.Misses)
+	}
+
+	if _, ok := c.Peek("missing"); ok {
+		t.Fatal("Peek(missing) = true, want false")
+	}
+}
+
+func TestRangeIteratesMRUToLRUWithoutDisturbingOrder(t *testing.T) {
+	c := New(0, nil)
+	c.Add("a", stringValue("1"))
+	c.Add("b", stringValue("2"))
+	c.Add("c", stringValue("3"))
+	// LRU 顺序（MRU->LRU）是 c, b, a
+
+	var gotKeys []string
+	c.Range(func(key string, value Value) bool {
+		gotKeys = append(gotKeys, key)
+		return true
+	})
+	want := []string{"c", "b", "a"}
+	for i, k := range want {
+		if gotKeys[i] != k {
+			t.Fatalf("Range order = %v, want %v", gotKeys, want)
+		}
+	}
+
+	if oldest := c.l1.Back().Value.(*entry).key; oldest != "a" {
+		t.Fatalf("Range disturbed LRU order: oldest = %q, want %q", oldest, "a")
+	}
+
+	var stoppedEarly []string
+	c.Range(func(key string, value Value) bool {
+		stoppedEarly = append(stoppedEarly, key)
+		return false
+	})
+	if len(stoppedEarly) != 1 || stoppedEarly[0] != "c" {
+		t.Fatalf("Range did not stop after fn returned false: got %v", stoppedEarly)
+	}
+}
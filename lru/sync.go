@@ -0,0 +1,151 @@
+package lru
+
+import (
+	"sync"
+	"time"
+)
+
+// SyncCache 是对 Cache 的并发安全包装。
+// 原始的 Cache 基于 map + container/list，多个 goroutine 并发读写会产生数据竞争，
+// 这里用一把 sync.RWMutex 保护所有对底层 Cache 的访问，对外暴露同样的 Get/Add/RemoveOldest/Len。
+type SyncCache struct {
+	mu    sync.RWMutex
+	cache *Cache
+}
+
+// NewSyncCache 创建一个并发安全的 Cache
+func NewSyncCache(maxBytes int, onEvicted func(string, Value)) *SyncCache {
+	return &SyncCache{
+		cache: New(maxBytes, onEvicted),
+	}
+}
+
+// Get 查找键对应的值，命中时会将该节点移动到队尾，因此和写操作一样需要互斥锁
+func (c *SyncCache) Get(key string) (value Value, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cache.Get(key)
+}
+
+// Add 新增或更新缓存
+func (c *SyncCache) Add(key string, value Value) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Add(key, value)
+}
+
+// RemoveOldest 移除最近最少访问的节点
+func (c *SyncCache) RemoveOldest() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.RemoveOldest()
+}
+
+// Len 返回缓存条目数
+func (c *SyncCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cache.Len()
+}
+
+// AddWithTTL 新增或更新缓存，并在 ttl 之后让该条目过期
+func (c *SyncCache) AddWithTTL(key string, value Value, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.AddWithTTL(key, value, ttl)
+}
+
+// StartJanitor 启动一个后台 goroutine，每隔 interval 在持有写锁的情况下清理已过期的条目，
+// 这样后台清理和 Get/Add 等前台调用就不会互相竞争。返回的 stop 函数用于停止该 goroutine。
+func (c *SyncCache) StartJanitor(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				c.mu.Lock()
+				c.cache.sweepExpired()
+				c.mu.Unlock()
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+	}
+}
+
+// ShardedCache 把 key 通过 fnv-1a 哈希分散到 N 个分片（N 向上取整为 2 的幂），
+// 每个分片都是一个独立的 SyncCache，容量为 maxBytes/N。
+// 这样可以把单把锁的竞争分摊到多把锁上，在高并发下获得更好的扩展性，同时不改变底层的 LRU 淘汰算法。
+type ShardedCache struct {
+	shards []*SyncCache
+	mask   uint32
+}
+
+// NewShardedCache 创建一个分片缓存，n 会被向上取整为 2 的幂次
+func NewShardedCache(n int, maxBytes int, onEvicted func(string, Value)) *ShardedCache {
+	n = nextPowerOfTwo(n)
+	shards := make([]*SyncCache, n)
+	perShard := maxBytes / n
+	for i := range shards {
+		shards[i] = NewSyncCache(perShard, onEvicted)
+	}
+	return &ShardedCache{
+		shards: shards,
+		mask:   uint32(n - 1),
+	}
+}
+
+// shard 返回 key 所属的分片
+func (c *ShardedCache) shard(key string) *SyncCache {
+	return c.shards[fnv32a(key)&c.mask]
+}
+
+// Get 查找键对应的值
+func (c *ShardedCache) Get(key string) (value Value, ok bool) {
+	return c.shard(key).Get(key)
+}
+
+// Add 新增或更新缓存
+func (c *ShardedCache) Add(key string, value Value) {
+	c.shard(key).Add(key, value)
+}
+
+// Len 返回所有分片的缓存条目数之和
+func (c *ShardedCache) Len() int {
+	total := 0
+	for _, s := range c.shards {
+		total += s.Len()
+	}
+	return total
+}
+
+// fnv32a 实现 FNV-1a 哈希算法，用于将字符串 key 映射到分片
+func fnv32a(s string) uint32 {
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+	hash := uint32(offset32)
+	for i := 0; i < len(s); i++ {
+		hash ^= uint32(s[i])
+		hash *= prime32
+	}
+	return hash
+}
+
+// nextPowerOfTwo 将 n 向上取整为 2 的幂次，n<=1 时返回 1
+func nextPowerOfTwo(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
@@ -0,0 +1,90 @@
+package lru
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSyncCacheGetAddRemoveOldest(t *testing.T) {
+	c := NewSyncCache(6, nil)
+	c.Add("a", stringValue("aa"))
+	c.Add("b", stringValue("bb"))
+	c.Add("c", stringValue("cc")) // 超过 maxBytes，淘汰最久未访问的 a
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("a should have been evicted")
+	}
+	if got, ok := c.Get("b"); !ok || got != stringValue("bb") {
+		t.Fatalf("Get(b) = %v, %v", got, ok)
+	}
+	if c.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", c.Len())
+	}
+
+	c.RemoveOldest() // Get(b) 之后顺序（MRU->LRU）是 b, c，移除 c
+	if _, ok := c.Get("c"); ok {
+		t.Fatal("c should have been removed by RemoveOldest")
+	}
+	if c.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1 after RemoveOldest", c.Len())
+	}
+}
+
+func TestSyncCacheConcurrentAccess(t *testing.T) {
+	c := NewSyncCache(0, nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				c.Add("k", stringValue("v"))
+				c.Get("k")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestSyncCacheJanitorExpiresUnderLock(t *testing.T) {
+	c := NewSyncCache(0, nil)
+	c.AddWithTTL("a", stringValue("1"), time.Millisecond)
+
+	stop := c.StartJanitor(5 * time.Millisecond)
+	defer stop()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if c.Len() == 0 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("janitor never swept the expired entry")
+}
+
+func TestShardedCacheDistributesAndAggregatesLen(t *testing.T) {
+	c := NewShardedCache(4, 400, nil)
+	for i := 0; i < 20; i++ {
+		c.Add(string(rune('a'+i)), stringValue("v"))
+	}
+	if c.Len() != 20 {
+		t.Fatalf("Len() = %d, want 20", c.Len())
+	}
+	for i := 0; i < 20; i++ {
+		key := string(rune('a' + i))
+		if _, ok := c.Get(key); !ok {
+			t.Fatalf("missing key %q", key)
+		}
+	}
+}
+
+func TestNextPowerOfTwo(t *testing.T) {
+	cases := map[int]int{0: 1, 1: 1, 2: 2, 3: 4, 4: 4, 5: 8, 9: 16}
+	for in, want := range cases {
+		if got := nextPowerOfTwo(in); got != want {
+			t.Fatalf("nextPowerOfTwo(%d) = %d, want %d", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package lru
+
+const (
+	cmsDepth      = 4             // 哈希函数个数
+	cmsWidth      = 1 << 14       // 每行的计数器个数（2^14）
+	cmsCounterMax = 15            // 4 bit 计数器的上限
+	cmsHalveEvery = 10 * cmsWidth // 每累计这么多次 Add 就把所有计数器减半，防止饱和、体现“最近”的频率
+)
+
+// countMinSketch 是一个 4 行 x 2^14 列、每个计数器 4 bit 的 count-min sketch，
+// 用来以很小的内存代价估算一个 key 最近被访问的频率。计数器两两打包进一个 byte 里。
+type countMinSketch struct {
+	counters  [cmsDepth][]byte
+	seeds     [cmsDepth]uint32
+	additions int
+}
+
+func newCountMinSketch() *countMinSketch {
+	cms := &countMinSketch{}
+	for i := 0; i < cmsDepth; i++ {
+		cms.counters[i] = make([]byte, cmsWidth/2)
+		cms.seeds[i] = uint32(0x9e3779b9 * (i + 1))
+	}
+	return cms
+}
+
+func (cms *countMinSketch) index(row int, key string) uint32 {
+	return fnv32aSeeded(key, cms.seeds[row]) % cmsWidth
+}
+
+// Add 把 key 在每一行的计数器加一（饱和到 cmsCounterMax），并在累计次数够多时整体减半
+func (cms *countMinSketch) Add(key string) {
+	for row := 0; row < cmsDepth; row++ {
+		incr4bit(cms.counters[row], cms.index(row, key))
+	}
+	cms.additions++
+	if cms.additions >= cmsHalveEvery {
+		cms.halve()
+		cms.additions = 0
+	}
+}
+
+// Estimate 返回 key 的频率估计值：四行里的最小值，这是 count-min sketch 减小过估的标准做法
+func (cms *countMinSketch) Estimate(key string) byte {
+	min := byte(cmsCounterMax)
+	for row := 0; row < cmsDepth; row++ {
+		if v := get4bit(cms.counters[row], cms.index(row, key)); v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// halve 把所有计数器右移一位，让频率估计值随时间衰减，只反映“最近”的访问模式
+func (cms *countMinSketch) halve() {
+	for row := 0; row < cmsDepth; row++ {
+		for i := range cms.counters[row] {
+			b := cms.counters[row][i]
+			lo := (b & 0x0F) >> 1
+			hi := (b >> 4) >> 1
+			cms.counters[row][i] = lo | (hi << 4)
+		}
+	}
+}
+
+// get4bit / incr4bit 操作打包进一个 byte 的两个 4 bit 计数器，idx 为偶数取低 4 位，奇数取高 4 位
+func get4bit(counters []byte, idx uint32) byte {
+	b := counters[idx/2]
+	if idx%2 == 0 {
+		return b & 0x0F
+	}
+	return (b >> 4) & 0x0F
+}
+
+func incr4bit(counters []byte, idx uint32) {
+	i := idx / 2
+	if idx%2 == 0 {
+		if v := counters[i] & 0x0F; v < cmsCounterMax {
+			counters[i]++
+		}
+	} else {
+		if v := (counters[i] >> 4) & 0x0F; v < cmsCounterMax {
+			counters[i] += 1 << 4
+		}
+	}
+}
+
+// fnv32aSeeded 是带种子的 FNV-1a，用来给 count-min sketch 的每一行派生出互相独立的哈希函数
+func fnv32aSeeded(s string, seed uint32) uint32 {
+	const prime32 = 16777619
+	hash := uint32(2166136261) ^ seed
+	for i := 0; i < len(s); i++ {
+		hash ^= uint32(s[i])
+		hash *= prime32
+	}
+	return hash
+}
+
+// TinyLFUCache 在一个普通的 LRU 主存储前面加了一层基于 count-min sketch 的准入过滤器：
+// 只有当新 key 的历史访问频率高于将要被淘汰的 victim 时，才允许它替换 victim 进入缓存，
+// 这样偶发的一次性扫描不会把真正的热点数据挤出去（即“扫描抵抗”）。
+type TinyLFUCache struct {
+	cache  *Cache
+	sketch *countMinSketch
+}
+
+// NewTinyLFU 创建一个 TinyLFU 策略的缓存
+func NewTinyLFU(maxBytes int, onEvicted func(string, Value)) *TinyLFUCache {
+	return &TinyLFUCache{
+		cache:  New(maxBytes, onEvicted),
+		sketch: newCountMinSketch(),
+	}
+}
+
+// Get 查找键对应的值，同时把这次访问计入 sketch
+func (t *TinyLFUCache) Get(key string) (value Value, ok bool) {
+	v, ok := t.cache.Get(key)
+	t.sketch.Add(key)
+	return v, ok
+}
+
+// Add 新增或更新缓存。已存在的 key 直接更新；新 key 只有在不需要淘汰别人，
+// 或者它的估计频率高于 victim 时才会被接纳，否则本次写入被拒绝（不会进入缓存）
+func (t *TinyLFUCache) Add(key string, value Value) {
+	t.sketch.Add(key)
+	if _, ok := t.cache.cache[key]; ok {
+		t.cache.Add(key, value)
+		return
+	}
+	if t.cache.maxBytes != 0 && t.cache.nbytes+len(key)+value.Len() > t.cache.maxBytes {
+		if victim := t.cache.l1.Back(); victim != nil {
+			victimKey := victim.Value.(*entry).key
+			if t.sketch.Estimate(key) <= t.sketch.Estimate(victimKey) {
+				return // 新 key 不够“热”，拒绝准入，保留 victim
+			}
+		}
+	}
+	t.cache.Add(key, value)
+}
+
+// Remove 主动移除一个 key，key 不存在时返回 false
+func (t *TinyLFUCache) Remove(key string) bool {
+	return t.cache.Remove(key)
+}
+
+// Evict 按主存储（LRU）的顺序淘汰一个条目
+func (t *TinyLFUCache) Evict() {
+	t.cache.Evict()
+}
+
+// Len 返回缓存条目数
+func (t *TinyLFUCache) Len() int {
+	return t.cache.Len()
+}
+
+// Bytes 返回当前已使用的内存
+func (t *TinyLFUCache) Bytes() int {
+	return t.cache.Bytes()
+}
+
+var _ Policy = (*TinyLFUCache)(nil)
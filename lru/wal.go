@@ -0,0 +1,101 @@
+package lru
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+)
+
+// WAL 记录的操作类型
+const (
+	walOpAdd    byte = 0
+	walOpRemove byte = 1
+)
+
+// EnableWAL 打开（或创建）path 处的预写日志文件，之后每次 Add/RemoveOldest 都会追加一条记录，
+// 这样进程崩溃重启后可以通过 RecoverFromWAL 重建崩溃前的热点数据，避免冷缓存打垮数据库。
+func (c *Cache) EnableWAL(path string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	c.wal = f
+	return nil
+}
+
+// appendWAL 追加一条 WAL 记录：op 字节 + 长度前缀(key) + 长度前缀(value) + CRC32。
+// 写入失败只打印警告而不向上传播 —— Add/RemoveOldest 本身的签名不返回 error，
+// WAL 只是尽力而为的辅助手段，不应该让主路径的写缓存操作失败。
+func (c *Cache) appendWAL(op byte, key string, raw []byte) {
+	buf := append([]byte{op}, encodeRecord(key, raw)...)
+	crc := crc32.ChecksumIEEE(buf)
+	if err := binary.Write(c.wal, binary.BigEndian, uint32(len(buf))); err == nil {
+		if _, err = c.wal.Write(buf); err == nil {
+			err = binary.Write(c.wal, binary.BigEndian, crc)
+		}
+		if err != nil {
+			log.Printf("lru: WAL: write failed: %v", err)
+		}
+	} else {
+		log.Printf("lru: WAL: write failed: %v", err)
+	}
+}
+
+// readWALRecord 读取一条由 appendWAL 写出的记录并校验 CRC32
+func readWALRecord(r io.Reader) (op byte, key string, raw []byte, err error) {
+	var size uint32
+	if err = binary.Read(r, binary.BigEndian, &size); err != nil {
+		return
+	}
+	buf := make([]byte, size)
+	if _, err = io.ReadFull(r, buf); err != nil {
+		return
+	}
+	var wantCRC uint32
+	if err = binary.Read(r, binary.BigEndian, &wantCRC); err != nil {
+		return
+	}
+	if crc32.ChecksumIEEE(buf) != wantCRC {
+		err = fmt.Errorf("lru: corrupt WAL record: crc32 mismatch")
+		return
+	}
+	op = buf[0]
+	key, raw, err = decodeRecord(buf[1:])
+	return
+}
+
+// RecoverFromWAL 按顺序重放 path 处的 WAL 文件，重建一个新的 Cache。
+// maxBytes 的语义和 New 完全一致（0 表示不限制），由调用方显式指定，不会根据 WAL 里
+// 恢复出多少字节自行推断——文件不存在或为空时这样才不会意外变成一个无限容量的缓存。
+// 文件不存在时直接返回一个空缓存而不是错误，方便“首次启动”的场景。
+func RecoverFromWAL(path string, maxBytes int, newValue func(key string, raw []byte) Value, onEvicted func(string, Value)) (*Cache, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return New(maxBytes, onEvicted), nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	c := New(maxBytes, onEvicted)
+	for {
+		op, key, raw, err := readWALRecord(f)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case walOpAdd:
+			c.Add(key, newValue(key, raw))
+		case walOpRemove:
+			c.Remove(key)
+		}
+	}
+	return c, nil
+}
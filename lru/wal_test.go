@@ -0,0 +1,83 @@
+package lru
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestWALRecoverRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.wal")
+
+	// WAL 只记录 Add/RemoveOldest（见 EnableWAL 的文档），所以这里用容量淘汰
+	// 而不是手动 Remove 来触发一条 walOpRemove 记录。
+	c := New(12, nil)
+	if err := c.EnableWAL(path); err != nil {
+		t.Fatalf("EnableWAL: %v", err)
+	}
+	c.Add("a", stringValue("12345")) // nbytes = 6
+	c.Add("b", stringValue("12345")) // nbytes = 12
+	c.Add("c", stringValue("12345")) // 超过 maxBytes，RemoveOldest 把最久未访问的 "a" 挤出去
+
+	recovered, err := RecoverFromWAL(path, 12, newStringValue, nil)
+	if err != nil {
+		t.Fatalf("RecoverFromWAL: %v", err)
+	}
+	if recovered.maxBytes != 12 {
+		t.Fatalf("recovered.maxBytes = %d, want 12 (the caller-supplied budget, not an inferred one)", recovered.maxBytes)
+	}
+	if _, ok := recovered.Get("a"); ok {
+		t.Fatal("recovered still has key \"a\" which was evicted before recovery")
+	}
+	for key, want := range map[string]stringValue{"b": "12345", "c": "12345"} {
+		got, ok := recovered.Get(key)
+		if !ok {
+			t.Fatalf("recovered missing key %q", key)
+		}
+		if got != want {
+			t.Fatalf("recovered[%q] = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestWALRecoverFromMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist.wal")
+	c, err := RecoverFromWAL(path, 42, newStringValue, nil)
+	if err != nil {
+		t.Fatalf("RecoverFromWAL: %v", err)
+	}
+	if c.Len() != 0 {
+		t.Fatalf("c.Len() = %d, want 0", c.Len())
+	}
+	if c.maxBytes != 42 {
+		t.Fatalf("c.maxBytes = %d, want 42 (the caller-supplied budget)", c.maxBytes)
+	}
+}
+
+// TestRecoverFromEmptyWALDoesNotBecomeUnlimited 回归测试：恢复一个从未写入过任何记录的 WAL
+// （例如进程在第一次写入之前就崩溃了）不能因为 nbytes 恰好是 0 就静默变成无限容量的缓存。
+func TestRecoverFromEmptyWALDoesNotBecomeUnlimited(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.wal")
+	c := New(0, nil)
+	if err := c.EnableWAL(path); err != nil {
+		t.Fatalf("EnableWAL: %v", err)
+	}
+	c.wal.Close() // 只创建空文件，不写入任何记录，模拟“第一次写入之前崩溃”
+
+	recovered, err := RecoverFromWAL(path, 5, newStringValue, nil)
+	if err != nil {
+		t.Fatalf("RecoverFromWAL: %v", err)
+	}
+	if recovered.maxBytes != 5 {
+		t.Fatalf("recovered.maxBytes = %d, want 5, got silently-unlimited cache from an empty WAL", recovered.maxBytes)
+	}
+}
+
+// TestEvictionWithoutWALStaysNil 回归测试：从未调用过 EnableWAL 的 Cache 发生容量淘汰时，
+// c.wal 必须一直是 nil，不能误触发对 nil *os.File 的写入
+func TestEvictionWithoutWALStaysNil(t *testing.T) {
+	c := New(10, nil)
+	c.Add("a", stringValue("01234567890123456789")) // 超过 maxBytes，触发 RemoveOldest
+	if c.wal != nil {
+		t.Fatal("c.wal should stay nil when EnableWAL was never called")
+	}
+}